@@ -0,0 +1,102 @@
+package common
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/Faire/archer/lib/archer"
+)
+
+// SQLSchemaDriver is implemented per-dialect (postgres, ...);
+// ImportSQLSchema does the shared work of turning its results into Projects.
+type SQLSchemaDriver interface {
+	QueryTables(db *sql.DB) ([]SQLTableInfo, error)
+	QueryForeignKeys(db *sql.DB) ([]SQLForeignKey, error)
+}
+
+type SQLTableInfo struct {
+	SchemaName string
+	TableName  string
+	Rows       int
+	DataSize   int
+	IndexSize  int
+}
+
+type SQLForeignKey struct {
+	SchemaName          string
+	TableName           string
+	ReferencedTableName string
+}
+
+// ImportSQLSchema walks the driver's tables and foreign keys into projs and
+// writes them out through storage.
+func ImportSQLSchema(db *sql.DB, driver SQLSchemaDriver, projs *archer.Projects, storage *archer.Storage) error {
+	tables, err := driver.QueryTables(db)
+	if err != nil {
+		return errors.Wrap(err, "error querying database tables")
+	}
+
+	var changedProjs []*archer.Project
+
+	for _, table := range tables {
+		proj := projs.Get(table.SchemaName, table.TableName)
+		proj.Type = archer.DatabaseType
+
+		proj.AddSize("table", archer.Size{
+			Lines: table.Rows,
+			Bytes: table.DataSize + table.IndexSize,
+			Other: map[string]int{
+				"data":    table.DataSize,
+				"indexes": table.IndexSize,
+			},
+		})
+
+		changedProjs = append(changedProjs, proj)
+	}
+
+	CreateTableNameParts(changedProjs)
+
+	for _, proj := range changedProjs {
+		err = storage.WriteBasicInfoFile(proj)
+		if err != nil {
+			return err
+		}
+
+		err = storage.WriteSizeFile(proj)
+		if err != nil {
+			return err
+		}
+	}
+
+	fks, err := driver.QueryForeignKeys(db)
+	if err != nil {
+		return errors.Wrap(err, "error querying database FKs")
+	}
+
+	type rootAndName struct {
+		root string
+		name string
+	}
+	toSave := map[rootAndName]bool{}
+
+	for _, fk := range fks {
+		proj := projs.Get(fk.SchemaName, fk.TableName)
+
+		dep := projs.Get(fk.SchemaName, fk.ReferencedTableName)
+		proj.AddDependency(dep)
+
+		toSave[rootAndName{fk.SchemaName, fk.TableName}] = true
+	}
+
+	for k := range toSave {
+		proj := projs.Get(k.root, k.name)
+
+		err = storage.WriteDepsFile(proj)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
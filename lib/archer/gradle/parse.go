@@ -55,6 +55,11 @@ func parseProjects(content string) ([]string, error) {
 	return result, nil
 }
 
+// parseDeps builds the CodeType project graph from `gradlew dependencies`
+// output. Projects here are Gradle modules, not database tables, so there's
+// no table-column analog for them to populate via AddColumn - that part of
+// archer.Project is left to the DB-schema importers that populate it
+// (mysql, sqlddl, orm).
 func parseDeps(projects *archer.Projects, content string, rootProj string) error {
 	rootProjRE := regexp.MustCompile(`^(?:Root project|Project) '([^']+)'$`)
 	depRE := regexp.MustCompile(`^([-+\\| ]+)(?:project )?([a-zA-Z0-9:._-]+)`)
@@ -0,0 +1,22 @@
+package orm
+
+// entity is the language-agnostic shape both the Go and Kotlin scanners
+// reduce their findings to.
+type entity struct {
+	name    string
+	columns []entityColumn
+	fks     []entityFK
+}
+
+type entityColumn struct {
+	name     string
+	typeName string
+	nullable bool
+	isPK     bool
+}
+
+type entityFK struct {
+	column           string
+	referencedTable  string
+	referencedColumn string
+}
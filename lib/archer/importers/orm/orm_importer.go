@@ -0,0 +1,188 @@
+package orm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/Faire/archer/lib/archer"
+	"github.com/Faire/archer/lib/archer/common"
+)
+
+// ormImporter scans a source tree for ORM-annotated Go structs (xorm/gorm
+// tags) and Kotlin classes (JPA annotations) and materializes them as
+// DatabaseType Projects.
+type ormImporter struct {
+	schema string
+	paths  []string
+
+	storage *archer.Storage
+}
+
+// NewImporter creates an importer that walks the given files and
+// directories (directories are walked recursively) for .go and .kt source.
+func NewImporter(schema string, paths ...string) archer.Importer {
+	return &ormImporter{
+		schema: schema,
+		paths:  paths,
+	}
+}
+
+func (m *ormImporter) Import(projs *archer.Projects, storage *archer.Storage) error {
+	m.storage = storage
+
+	files, err := m.listFiles()
+	if err != nil {
+		return err
+	}
+
+	var goStructs []rawGoStruct
+	var ktClasses []rawKtClass
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return errors.Wrapf(err, "error reading %v", file)
+		}
+
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".go":
+			goStructs = append(goStructs, parseGoSource(string(content))...)
+		case ".kt":
+			ktClasses = append(ktClasses, parseKotlinSource(string(content))...)
+		}
+	}
+
+	tableNames := map[string]string{}
+	for _, s := range goStructs {
+		tableNames[s.structName] = s.tableName
+	}
+	for _, c := range ktClasses {
+		tableNames[c.className] = c.tableName
+	}
+
+	entities := make([]entity, 0, len(goStructs)+len(ktClasses))
+	for _, s := range goStructs {
+		entities = append(entities, goEntityToEntity(s, tableNames))
+	}
+	for _, c := range ktClasses {
+		entities = append(entities, ktClassToEntity(c, tableNames))
+	}
+
+	var changedProjs []*archer.Project
+
+	for _, e := range entities {
+		proj := projs.Get(m.schema, e.name)
+		proj.Type = archer.DatabaseType
+
+		for _, col := range e.columns {
+			proj.AddColumn(&archer.Column{
+				Name:     col.name,
+				Type:     col.typeName,
+				Nullable: col.nullable,
+				IsPK:     col.isPK,
+				IsUnique: col.isPK,
+			})
+		}
+
+		changedProjs = append(changedProjs, proj)
+	}
+
+	common.CreateTableNameParts(changedProjs)
+
+	for _, proj := range changedProjs {
+		err = m.storage.WriteBasicInfoFile(proj)
+		if err != nil {
+			return err
+		}
+
+		err = m.storage.WriteColumnsFile(proj)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, e := range entities {
+		if len(e.fks) == 0 {
+			continue
+		}
+
+		err = m.importEntityDeps(projs, e)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ormImporter) importEntityDeps(projs *archer.Projects, e entity) error {
+	proj := projs.Get(m.schema, e.name)
+
+	var order []string
+	columns := map[string][]archer.ColumnPair{}
+
+	for _, fk := range e.fks {
+		if _, ok := columns[fk.referencedTable]; !ok {
+			order = append(order, fk.referencedTable)
+		}
+
+		dep := projs.Get(m.schema, fk.referencedTable)
+
+		columns[fk.referencedTable] = append(columns[fk.referencedTable], archer.ColumnPair{
+			Source: proj.GetColumn(fk.column),
+			Target: dep.GetColumn(fk.referencedColumn),
+		})
+	}
+
+	for _, target := range order {
+		fmt.Printf("Importing dependency %v.%v => %v.%v\n", m.schema, e.name, m.schema, target)
+
+		dep := projs.Get(m.schema, target)
+		proj.AddDependency(dep, columns[target]...)
+	}
+
+	err := m.storage.WriteDepsFile(proj)
+	if err != nil {
+		return err
+	}
+
+	return m.storage.WriteSchemaFile(proj)
+}
+
+func (m *ormImporter) listFiles() ([]string, error) {
+	var result []string
+
+	for _, path := range m.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading %v", path)
+		}
+
+		if !info.IsDir() {
+			result = append(result, path)
+			continue
+		}
+
+		err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			ext := strings.ToLower(filepath.Ext(p))
+			if !info.IsDir() && (ext == ".go" || ext == ".kt") {
+				result = append(result, p)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "error walking %v", path)
+		}
+	}
+
+	return result, nil
+}
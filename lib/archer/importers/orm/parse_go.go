@@ -0,0 +1,164 @@
+package orm
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	goStructRE    = regexp.MustCompile(`(?s)type\s+([A-Za-z0-9_]+)\s+struct\s*\{(.*?)\n\}`)
+	goFieldRE     = regexp.MustCompile("(?m)^\\s*([A-Za-z0-9_]+)\\s+(\\*?\\[\\]?\\*?[A-Za-z0-9_.]+)\\s+`([^`]*)`")
+	goTableNameRE = regexp.MustCompile(`(?s)func\s*\((?:[A-Za-z0-9_]+\s+)?\*?([A-Za-z0-9_]+)\)\s*TableName\(\)\s*string\s*\{\s*return\s*"([^"]+)"`)
+
+	gormForeignKeyRE = regexp.MustCompile(`foreignKey:([A-Za-z0-9_]+)`)
+	gormReferencesRE = regexp.MustCompile(`references:([A-Za-z0-9_]+)`)
+	gormColumnRE     = regexp.MustCompile(`column:([a-zA-Z0-9_]+)`)
+	xormNameRE       = regexp.MustCompile(`'([a-zA-Z0-9_]+)'`)
+)
+
+type rawGoStruct struct {
+	structName string
+	tableName  string
+	hasORMTag  bool
+	fields     []rawGoField
+}
+
+type rawGoField struct {
+	name     string
+	typeName string
+	tag      string
+}
+
+// parseGoSource extracts every xorm/gorm-tagged struct declared in a .go
+// file. Table names come from an explicit TableName() method when present,
+// falling back to the snake_cased struct name.
+func parseGoSource(content string) []rawGoStruct {
+	tableNames := map[string]string{}
+	for _, m := range goTableNameRE.FindAllStringSubmatch(content, -1) {
+		tableNames[m[1]] = m[2]
+	}
+
+	var result []rawGoStruct
+
+	for _, m := range goStructRE.FindAllStringSubmatch(content, -1) {
+		s := rawGoStruct{structName: m[1]}
+		body := m[2]
+
+		for _, fm := range goFieldRE.FindAllStringSubmatch(body, -1) {
+			tag := fm[3]
+
+			if strings.Contains(tag, "xorm:") || strings.Contains(tag, "gorm:") {
+				s.hasORMTag = true
+			}
+
+			s.fields = append(s.fields, rawGoField{name: fm[1], typeName: fm[2], tag: tag})
+		}
+
+		if !s.hasORMTag {
+			continue
+		}
+
+		if tableName, ok := tableNames[s.structName]; ok {
+			s.tableName = tableName
+		} else {
+			s.tableName = toSnakeCase(s.structName)
+		}
+
+		result = append(result, s)
+	}
+
+	return result
+}
+
+// goEntityToEntity resolves a raw struct's fields into columns and FKs. A
+// gorm association (a field whose tag carries foreignKey:) becomes an
+// entityFK rather than a column.
+func goEntityToEntity(s rawGoStruct, tableNames map[string]string) entity {
+	e := entity{name: s.tableName}
+
+	for _, f := range s.fields {
+		if m := gormForeignKeyRE.FindStringSubmatch(f.tag); m != nil {
+			targetStruct := strings.TrimPrefix(strings.TrimPrefix(f.typeName, "*"), "[]")
+			targetStruct = strings.TrimPrefix(targetStruct, "*")
+
+			targetTable, ok := tableNames[targetStruct]
+			if !ok {
+				continue
+			}
+
+			refColumn := "id"
+			if rm := gormReferencesRE.FindStringSubmatch(f.tag); rm != nil {
+				refColumn = toSnakeCase(rm[1])
+			}
+
+			e.fks = append(e.fks, entityFK{
+				column:           toSnakeCase(m[1]),
+				referencedTable:  targetTable,
+				referencedColumn: refColumn,
+			})
+
+			continue
+		}
+
+		if !strings.Contains(f.tag, "xorm:") && !strings.Contains(f.tag, "gorm:") {
+			continue
+		}
+
+		col := entityColumn{
+			name:     toSnakeCase(f.name),
+			typeName: f.typeName,
+			nullable: true,
+		}
+
+		if cm := gormColumnRE.FindStringSubmatch(f.tag); cm != nil {
+			col.name = cm[1]
+		} else if xm := xormNameRE.FindStringSubmatch(f.tag); xm != nil {
+			col.name = xm[1]
+		}
+
+		if strings.Contains(f.tag, "pk") || strings.Contains(f.tag, "primaryKey") || strings.Contains(f.tag, "primary_key") {
+			col.isPK = true
+			col.nullable = false
+		}
+
+		if strings.Contains(f.tag, "notnull") || strings.Contains(f.tag, "not null") {
+			col.nullable = false
+		}
+
+		e.columns = append(e.columns, col)
+	}
+
+	return e
+}
+
+// toSnakeCase only breaks a word at a lower->upper transition, or before an
+// upper letter that starts a new word (an upper immediately followed by a
+// lower). That keeps acronym runs like the "ID" in "UserID"/"OrgID" intact
+// as a single word ("user_id"/"org_id") instead of splitting every letter.
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && isUpper(r) {
+			prevUpper := isUpper(runes[i-1])
+			nextLower := i+1 < len(runes) && isLower(runes[i+1])
+
+			if !prevUpper || nextLower {
+				b.WriteByte('_')
+			}
+		}
+
+		b.WriteRune(r)
+	}
+
+	return strings.ToLower(b.String())
+}
+
+func isUpper(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
+
+func isLower(r rune) bool {
+	return r >= 'a' && r <= 'z'
+}
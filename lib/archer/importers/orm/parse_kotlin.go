@@ -0,0 +1,175 @@
+package orm
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	ktClassRE            = regexp.MustCompile(`(?s)@Table\s*\(([^)]*)\)[^{]*?class\s+([A-Za-z0-9_]+)\s*\(`)
+	ktPropertyRE         = regexp.MustCompile(`((?:@[A-Za-z0-9_]+(?:\([^)]*\))?\s*)*)(?:val|var)\s+([A-Za-z0-9_]+)\s*:\s*([A-Za-z0-9_.]+\??)`)
+	ktAnnotationRE       = regexp.MustCompile(`@([A-Za-z0-9_]+)(?:\(([^)]*)\))?`)
+	ktNameRE             = regexp.MustCompile(`name\s*=\s*"([^"]+)"`)
+	ktReferencedColumnRE = regexp.MustCompile(`referencedColumnName\s*=\s*"([^"]+)"`)
+	ktNullableFalseRE    = regexp.MustCompile(`nullable\s*=\s*false`)
+)
+
+type rawKtClass struct {
+	className string
+	tableName string
+	fields    []rawKtField
+}
+
+type rawKtField struct {
+	name        string
+	typeName    string
+	annotations []rawAnnotation
+}
+
+type rawAnnotation struct {
+	name string
+	args string
+}
+
+// parseKotlinSource extracts every class annotated with @Table(...) that
+// declares a primary constructor from a .kt file, along with each
+// constructor property's annotations (@Id, @Column, @ManyToOne,
+// @JoinColumn, ...). A bare @Table without parens, or a class without a
+// primary constructor, is not matched.
+func parseKotlinSource(content string) []rawKtClass {
+	var result []rawKtClass
+
+	for _, m := range ktClassRE.FindAllStringSubmatch(content, -1) {
+		c := rawKtClass{className: m[2], tableName: toSnakeCase(m[2])}
+
+		if nm := ktNameRE.FindStringSubmatch(m[1]); nm != nil {
+			c.tableName = nm[1]
+		}
+
+		start := strings.Index(content, m[0])
+		if start < 0 {
+			result = append(result, c)
+			continue
+		}
+
+		// m[0] (and so start) lands at "@Table(", not the constructor's own
+		// paren - ktClassRE ends in \s*\( though, so m[0]'s last byte already
+		// is that opening paren. Shifting start there keeps
+		// extractConstructorBody from grabbing @Table's own args instead.
+		start += len(m[0]) - 1
+
+		for _, pm := range ktPropertyRE.FindAllStringSubmatch(extractConstructorBody(content[start:]), -1) {
+			f := rawKtField{name: pm[2], typeName: pm[3]}
+
+			for _, am := range ktAnnotationRE.FindAllStringSubmatch(pm[1], -1) {
+				f.annotations = append(f.annotations, rawAnnotation{name: am[1], args: am[2]})
+			}
+
+			c.fields = append(c.fields, f)
+		}
+
+		result = append(result, c)
+	}
+
+	return result
+}
+
+// extractConstructorBody returns the text between the first matching pair
+// of parens in s, i.e. a Kotlin primary constructor's argument list.
+func extractConstructorBody(s string) string {
+	start := strings.IndexByte(s, '(')
+	if start < 0 {
+		return ""
+	}
+
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s[start+1 : i]
+			}
+		}
+	}
+
+	return s[start+1:]
+}
+
+// ktClassToEntity resolves a raw class's properties into columns and FKs. A
+// @ManyToOne/@OneToOne property becomes an entityFK using its @JoinColumn.
+func ktClassToEntity(c rawKtClass, tableNames map[string]string) entity {
+	e := entity{name: c.tableName}
+
+	for _, f := range c.fields {
+		isAssociation := false
+		joinColumnArgs := ""
+		isPK := false
+
+		col := entityColumn{
+			name:     toSnakeCase(f.name),
+			typeName: f.typeName,
+			nullable: strings.HasSuffix(f.typeName, "?"),
+		}
+
+		for _, a := range f.annotations {
+			switch a.name {
+			case "ManyToOne", "OneToOne":
+				isAssociation = true
+			case "JoinColumn":
+				joinColumnArgs = a.args
+			case "Id":
+				isPK = true
+			case "Column":
+				if nm := ktNameRE.FindStringSubmatch(a.args); nm != nil {
+					col.name = nm[1]
+				}
+				if ktNullableFalseRE.MatchString(a.args) {
+					col.nullable = false
+				}
+			}
+		}
+
+		if isAssociation {
+			// A nullable association ("val manager: Employee?", the usual
+			// way to express an optional @ManyToOne) carries the Kotlin
+			// "?" into f.typeName, but tableNames is keyed by bare class
+			// names - strip it before the lookup or every optional
+			// relation silently fails to resolve.
+			targetTable, ok := tableNames[strings.TrimSuffix(f.typeName, "?")]
+			if !ok {
+				continue
+			}
+
+			fkColumn := toSnakeCase(f.name) + "_id"
+			refColumn := "id"
+
+			if nm := ktNameRE.FindStringSubmatch(joinColumnArgs); nm != nil {
+				fkColumn = nm[1]
+			}
+
+			if rm := ktReferencedColumnRE.FindStringSubmatch(joinColumnArgs); rm != nil {
+				refColumn = rm[1]
+			}
+
+			e.fks = append(e.fks, entityFK{
+				column:           fkColumn,
+				referencedTable:  targetTable,
+				referencedColumn: refColumn,
+			})
+
+			continue
+		}
+
+		col.isPK = isPK
+		if isPK {
+			col.nullable = false
+		}
+
+		e.columns = append(e.columns, col)
+	}
+
+	return e
+}
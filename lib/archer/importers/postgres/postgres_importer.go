@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/Faire/archer/lib/archer"
+	"github.com/Faire/archer/lib/archer/common"
+)
+
+type postgresImporter struct {
+	connectionString string
+	storage          *archer.Storage
+}
+
+func NewImporter(connectionString string) archer.Importer {
+	return &postgresImporter{
+		connectionString: connectionString,
+	}
+}
+
+func (m *postgresImporter) Import(projs *archer.Projects, storage *archer.Storage) error {
+	m.storage = storage
+
+	db, err := sql.Open("postgres", m.connectionString)
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to PostgreSQL using %v", m.connectionString)
+	}
+
+	defer db.Close()
+
+	db.SetConnMaxLifetime(time.Minute)
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	return common.ImportSQLSchema(db, m, projs, storage)
+}
+
+func (m *postgresImporter) QueryTables(db *sql.DB) ([]common.SQLTableInfo, error) {
+	results, err := db.Query(`
+		select t.table_schema                     schema_name,
+			   t.table_name                        table_name,
+			   coalesce(c.reltuples::bigint, 0)     rows,
+			   coalesce(pg_relation_size(c.oid), 0) data_size,
+			   coalesce(pg_indexes_size(c.oid), 0)  index_size
+		from information_schema.tables t
+				 join pg_class c on c.relname = t.table_name
+				 join pg_namespace n on n.oid = c.relnamespace and n.nspname = t.table_schema
+		where t.table_type = 'BASE TABLE'
+		  and t.table_schema not in ('information_schema', 'pg_catalog')
+		`)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying database tables")
+	}
+	defer results.Close()
+
+	var result []common.SQLTableInfo
+
+	for results.Next() {
+		var table common.SQLTableInfo
+
+		err = results.Scan(&table.SchemaName, &table.TableName, &table.Rows, &table.DataSize, &table.IndexSize)
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying database tables")
+		}
+
+		fmt.Printf("Importing table %v.%v (%v data, %v indexes)\n", table.SchemaName, table.TableName,
+			humanize.Bytes(uint64(table.DataSize)), humanize.Bytes(uint64(table.IndexSize)))
+
+		result = append(result, table)
+	}
+
+	return result, nil
+}
+
+func (m *postgresImporter) QueryForeignKeys(db *sql.DB) ([]common.SQLForeignKey, error) {
+	results, err := db.Query(`
+		select rc.constraint_schema schema_name,
+			   kcu.table_name,
+			   ccu.table_name       referenced_table_name
+		from information_schema.referential_constraints rc
+				 join information_schema.key_column_usage kcu
+					  on kcu.constraint_name = rc.constraint_name
+						  and kcu.constraint_schema = rc.constraint_schema
+				 join information_schema.key_column_usage ccu
+					  on ccu.constraint_name = rc.unique_constraint_name
+						  and ccu.constraint_schema = rc.unique_constraint_schema
+		`)
+	if err != nil {
+		return nil, errors.Wrap(err, "error querying database FKs")
+	}
+	defer results.Close()
+
+	var result []common.SQLForeignKey
+
+	for results.Next() {
+		var fk common.SQLForeignKey
+
+		err = results.Scan(&fk.SchemaName, &fk.TableName, &fk.ReferencedTableName)
+		if err != nil {
+			return nil, errors.Wrap(err, "error querying database FKs")
+		}
+
+		fmt.Printf("Importing dependency %v.%v => %v.%v\n",
+			fk.SchemaName, fk.TableName, fk.SchemaName, fk.ReferencedTableName)
+
+		result = append(result, fk)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,345 @@
+package sqlddl
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// identPair matches a possibly schema-qualified identifier where each side
+// of the dot may be independently backtick-quoted (`db`.`table`, `db.table`,
+// db.table, or just table) - MySQL dumps use all four forms interchangeably.
+// Capture group 1 is the identifier before the dot (or the whole name, if
+// there's no dot); group 2 is the part after the dot, if any.
+const identPair = "`?([a-zA-Z0-9_]+)`?(?:\\s*\\.\\s*`?([a-zA-Z0-9_]+)`?)?"
+
+var (
+	createTableHeaderRE = regexp.MustCompile(
+		"(?is)create\\s+table\\s+(?:if\\s+not\\s+exists\\s+)?" + identPair + "\\s*\\(")
+	alterAddFKRE = regexp.MustCompile(
+		"(?is)alter\\s+table\\s+" + identPair + "\\s+add\\s+(?:constraint\\s+`?[a-zA-Z0-9_]+`?\\s+)?" +
+			"foreign\\s+key\\s*\\(([^)]+)\\)\\s*references\\s+" + identPair + "\\s*\\(([^)]+)\\)")
+	constraintFKRE = regexp.MustCompile(
+		"(?is)^constraint\\s+`?[a-zA-Z0-9_]+`?\\s+foreign\\s+key\\s*\\(([^)]+)\\)\\s*references\\s+" +
+			identPair + "\\s*\\(([^)]+)\\)")
+	inlineFKRE = regexp.MustCompile(
+		"(?is)^foreign\\s+key\\s*\\(([^)]+)\\)\\s*references\\s+" + identPair + "\\s*\\(([^)]+)\\)")
+	primaryKeyRE     = regexp.MustCompile(`(?is)^primary\s+key\s*\(([^)]+)\)`)
+	reservedPrefixRE = regexp.MustCompile(`(?i)^(primary|unique|key|index|constraint|foreign|check)\b`)
+	columnDefRE      = regexp.MustCompile(
+		"(?is)^`?([a-zA-Z0-9_]+)`?\\s+([a-zA-Z][a-zA-Z0-9_]*(?:\\s*\\([^)]*\\))?(?:\\s+unsigned)?(?:\\s+zerofill)?)")
+	rowHintRE = regexp.MustCompile(`(?i)--\s*rows?(?:\s*[:=~]|\s+approx(?:imately)?)\s*([0-9][0-9,]*)`)
+)
+
+type parsedColumn struct {
+	name     string
+	typeName string
+	nullable bool
+	isPK     bool
+}
+
+type parsedTable struct {
+	name    string
+	rows    int
+	columns []parsedColumn
+}
+
+type parsedFK struct {
+	table             string
+	columns           []string
+	referencedTable   string
+	referencedColumns []string
+}
+
+// qualifiedName joins an identPair capture back into the "schema.table" (or
+// bare "table") form the rest of this package and sqlDdlImporter expect.
+func qualifiedName(first, second string) string {
+	if second == "" {
+		return strings.ToLower(first)
+	}
+
+	return strings.ToLower(first) + "." + strings.ToLower(second)
+}
+
+// parseDDL splits a SQL dump into its statements and extracts the subset of
+// DDL this importer understands: CREATE TABLE (columns, PRIMARY KEY, and
+// any CONSTRAINT/inline FOREIGN KEY clauses) and
+// ALTER TABLE ... ADD [CONSTRAINT ...] FOREIGN KEY. It is deliberately
+// tolerant: statements and table-definition items it doesn't recognize
+// (indexes, CHECK constraints, ...) are skipped rather than treated as
+// errors, since dumps routinely carry driver-specific noise (SET,
+// LOCK TABLES, COMMENT ON, ...) that isn't relevant to the schema graph.
+//
+// This is a hand-rolled tokenizer/regex walk rather than a generated ANTLR
+// grammar. There's no ANTLR toolchain wired into this repo's build (no .g4
+// grammar, no generated lexer/parser/visitor anywhere in the tree), and
+// every other external-output parser here (gradle's parseDeps) already
+// follows this same regex/state-machine style, so that's what this importer
+// matches rather than introducing a one-off code-generation step for a
+// single package. The tradeoff is real: a grammar would reject input this
+// can silently misparse, so the identPair/FK patterns above are kept as
+// close to the full CREATE/ALTER TABLE grammar as practical.
+func parseDDL(content string) ([]parsedTable, []parsedFK) {
+	var tables []parsedTable
+	var fks []parsedFK
+
+	for _, stmt := range splitStatements(content) {
+		trimmed := strings.TrimSpace(stmt)
+		if trimmed == "" {
+			continue
+		}
+
+		if m := createTableHeaderRE.FindStringSubmatchIndex(trimmed); m != nil {
+			name := qualifiedName(submatch(trimmed, m, 2), submatch(trimmed, m, 4))
+			openIdx := m[1] - 1
+
+			closeIdx := matchingParen(trimmed, openIdx)
+			if closeIdx < 0 {
+				continue
+			}
+
+			table, tableFks := parseCreateTableBody(name, trimmed[openIdx+1:closeIdx])
+
+			if hint := rowHintRE.FindStringSubmatch(trimmed); hint != nil {
+				if rows, err := strconv.Atoi(strings.ReplaceAll(hint[1], ",", "")); err == nil {
+					table.rows = rows
+				}
+			}
+
+			tables = append(tables, table)
+			fks = append(fks, tableFks...)
+
+			continue
+		}
+
+		if m := alterAddFKRE.FindStringSubmatch(trimmed); m != nil {
+			fks = append(fks, parsedFK{
+				table:             qualifiedName(m[1], m[2]),
+				columns:           splitIdentifierList(m[3]),
+				referencedTable:   qualifiedName(m[4], m[5]),
+				referencedColumns: splitIdentifierList(m[6]),
+			})
+		}
+	}
+
+	return tables, fks
+}
+
+// submatch returns the substring captured by group i in a
+// FindStringSubmatchIndex result, or "" if that (optional) group didn't
+// participate in the match.
+func submatch(s string, loc []int, i int) string {
+	if loc[i] < 0 {
+		return ""
+	}
+
+	return s[loc[i]:loc[i+1]]
+}
+
+// parseCreateTableBody classifies each comma-separated item inside a
+// CREATE TABLE(...) column list as a column definition, a PRIMARY KEY
+// clause, or a FOREIGN KEY clause (inline or via a named CONSTRAINT);
+// anything else (KEY/INDEX/UNIQUE/CHECK) is recognized and skipped.
+func parseCreateTableBody(tableName, body string) (parsedTable, []parsedFK) {
+	table := parsedTable{name: tableName}
+	pk := map[string]bool{}
+	var fks []parsedFK
+
+	for _, item := range splitTopLevel(body) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if m := primaryKeyRE.FindStringSubmatch(item); m != nil {
+			for _, col := range splitIdentifierList(m[1]) {
+				pk[col] = true
+			}
+			continue
+		}
+
+		if m := constraintFKRE.FindStringSubmatch(item); m != nil {
+			fks = append(fks, parsedFK{
+				table:             tableName,
+				columns:           splitIdentifierList(m[1]),
+				referencedTable:   qualifiedName(m[2], m[3]),
+				referencedColumns: splitIdentifierList(m[4]),
+			})
+			continue
+		}
+
+		if m := inlineFKRE.FindStringSubmatch(item); m != nil {
+			fks = append(fks, parsedFK{
+				table:             tableName,
+				columns:           splitIdentifierList(m[1]),
+				referencedTable:   qualifiedName(m[2], m[3]),
+				referencedColumns: splitIdentifierList(m[4]),
+			})
+			continue
+		}
+
+		if reservedPrefixRE.MatchString(item) {
+			continue
+		}
+
+		if m := columnDefRE.FindStringSubmatch(item); m != nil {
+			table.columns = append(table.columns, parsedColumn{
+				name:     strings.ToLower(m[1]),
+				typeName: strings.Join(strings.Fields(m[2]), " "),
+				nullable: !strings.Contains(strings.ToUpper(item), "NOT NULL"),
+			})
+		}
+	}
+
+	for i := range table.columns {
+		if pk[table.columns[i].name] {
+			table.columns[i].isPK = true
+			table.columns[i].nullable = false
+		}
+	}
+
+	return table, fks
+}
+
+// splitIdentifierList turns a "`a`, `b`" column list into []string{"a", "b"}.
+func splitIdentifierList(s string) []string {
+	var result []string
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		part = strings.Trim(part, "`\"")
+		part = strings.TrimSpace(part)
+
+		if part != "" {
+			result = append(result, strings.ToLower(part))
+		}
+	}
+
+	return result
+}
+
+// matchingParen returns the index of the ')' that closes the '(' at
+// s[openIdx], accounting for nesting (column types like decimal(10,2) and
+// clauses like PRIMARY KEY(...) both nest parens inside the table body).
+func matchingParen(s string, openIdx int) int {
+	depth := 0
+
+	for i := openIdx; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// splitTopLevel splits a CREATE TABLE column list on its top-level commas,
+// ignoring commas nested inside parens (column types, PRIMARY KEY(...), ...).
+func splitTopLevel(s string) []string {
+	var result []string
+	var b strings.Builder
+
+	depth := 0
+	inString := byte(0)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		if inString != 0 {
+			b.WriteByte(c)
+			if c == inString && (i == 0 || s[i-1] != '\\') {
+				inString = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"':
+			inString = c
+			b.WriteByte(c)
+		case '(':
+			depth++
+			b.WriteByte(c)
+		case ')':
+			depth--
+			b.WriteByte(c)
+		case ',':
+			if depth == 0 {
+				result = append(result, b.String())
+				b.Reset()
+				continue
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	if strings.TrimSpace(b.String()) != "" {
+		result = append(result, b.String())
+	}
+
+	return result
+}
+
+// splitStatements breaks a .sql dump into its top-level `;`-terminated
+// statements, ignoring semicolons inside string literals or line comments.
+func splitStatements(content string) []string {
+	var result []string
+	var b strings.Builder
+
+	inString := byte(0)
+	inLineComment := false
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+
+		if inLineComment {
+			b.WriteByte(c)
+			if c == '\n' {
+				inLineComment = false
+			}
+			continue
+		}
+
+		if inString != 0 {
+			b.WriteByte(c)
+			if c == inString && (i == 0 || content[i-1] != '\\') {
+				inString = 0
+			}
+			continue
+		}
+
+		if c == '\'' || c == '"' {
+			inString = c
+			b.WriteByte(c)
+			continue
+		}
+
+		if c == '-' && i+1 < len(content) && content[i+1] == '-' {
+			inLineComment = true
+			b.WriteByte(c)
+			continue
+		}
+
+		if c == ';' {
+			result = append(result, b.String())
+			b.Reset()
+			continue
+		}
+
+		b.WriteByte(c)
+	}
+
+	if strings.TrimSpace(b.String()) != "" {
+		result = append(result, b.String())
+	}
+
+	return result
+}
@@ -0,0 +1,209 @@
+package sqlddl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/Faire/archer/lib/archer"
+	"github.com/Faire/archer/lib/archer/common"
+)
+
+// sqlDdlImporter imports database structure from .sql dump files instead of
+// a live connection, so schemas can be ingested in CI (or from multi-file
+// migration directories) where no database is reachable.
+type sqlDdlImporter struct {
+	schema string
+	paths  []string
+
+	storage *archer.Storage
+}
+
+// NewImporter creates an importer that parses the CREATE TABLE and
+// ALTER TABLE statements found in the given files and directories
+// (directories are walked recursively for *.sql files). Table names that
+// aren't schema-qualified are assumed to belong to schema.
+func NewImporter(schema string, paths ...string) archer.Importer {
+	return &sqlDdlImporter{
+		schema: schema,
+		paths:  paths,
+	}
+}
+
+func (m *sqlDdlImporter) Import(projs *archer.Projects, storage *archer.Storage) error {
+	m.storage = storage
+
+	files, err := m.listFiles()
+	if err != nil {
+		return err
+	}
+
+	var changedProjs []*archer.Project
+	var fks []parsedFK
+
+	for _, file := range files {
+		fmt.Printf("Parsing %v\n", file)
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return errors.Wrapf(err, "error reading %v", file)
+		}
+
+		tables, tableFks := parseDDL(string(content))
+
+		for _, table := range tables {
+			proj := projs.Get(m.schemaOf(table.name), m.nameOf(table.name))
+			proj.Type = archer.DatabaseType
+
+			if table.rows > 0 {
+				proj.AddSize("table", archer.Size{
+					Lines: table.rows,
+				})
+			}
+
+			for _, col := range table.columns {
+				proj.AddColumn(&archer.Column{
+					Name:     col.name,
+					Type:     col.typeName,
+					Nullable: col.nullable,
+					IsPK:     col.isPK,
+					IsUnique: col.isPK,
+				})
+			}
+
+			changedProjs = append(changedProjs, proj)
+		}
+
+		fks = append(fks, tableFks...)
+	}
+
+	common.CreateTableNameParts(changedProjs)
+
+	for _, proj := range changedProjs {
+		err = m.storage.WriteBasicInfoFile(proj)
+		if err != nil {
+			return err
+		}
+
+		err = m.storage.WriteSizeFile(proj)
+		if err != nil {
+			return err
+		}
+
+		err = m.storage.WriteColumnsFile(proj)
+		if err != nil {
+			return err
+		}
+	}
+
+	type fkKey struct {
+		schema   string
+		table    string
+		refTable string
+	}
+
+	var order []fkKey
+	columns := map[fkKey][]archer.ColumnPair{}
+
+	for _, fk := range fks {
+		schema, table := m.schemaOf(fk.table), m.nameOf(fk.table)
+		refSchema, refTable := m.schemaOf(fk.referencedTable), m.nameOf(fk.referencedTable)
+
+		proj := projs.Get(schema, table)
+		dep := projs.Get(refSchema, refTable)
+
+		key := fkKey{schema, table, fk.referencedTable}
+		if _, ok := columns[key]; !ok {
+			order = append(order, key)
+		}
+
+		n := len(fk.columns)
+		if len(fk.referencedColumns) < n {
+			n = len(fk.referencedColumns)
+		}
+
+		for i := 0; i < n; i++ {
+			columns[key] = append(columns[key], archer.ColumnPair{
+				Source: proj.GetColumn(fk.columns[i]),
+				Target: dep.GetColumn(fk.referencedColumns[i]),
+			})
+		}
+	}
+
+	toSave := map[string]*archer.Project{}
+
+	for _, key := range order {
+		proj := projs.Get(key.schema, key.table)
+		dep := projs.Get(m.schemaOf(key.refTable), m.nameOf(key.refTable))
+
+		proj.AddDependency(dep, columns[key]...)
+
+		toSave[proj.FullName()] = proj
+	}
+
+	for _, proj := range toSave {
+		err = m.storage.WriteDepsFile(proj)
+		if err != nil {
+			return err
+		}
+
+		err = m.storage.WriteSchemaFile(proj)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *sqlDdlImporter) listFiles() ([]string, error) {
+	var result []string
+
+	for _, path := range m.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading %v", path)
+		}
+
+		if !info.IsDir() {
+			result = append(result, path)
+			continue
+		}
+
+		err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !info.IsDir() && strings.EqualFold(filepath.Ext(p), ".sql") {
+				result = append(result, p)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "error walking %v", path)
+		}
+	}
+
+	return result, nil
+}
+
+func (m *sqlDdlImporter) schemaOf(name string) string {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		return name[:i]
+	}
+
+	return m.schema
+}
+
+func (m *sqlDdlImporter) nameOf(name string) string {
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		return name[i+1:]
+	}
+
+	return name
+}
@@ -18,6 +18,8 @@ type Project struct {
 	Dir         string
 	ProjectFile string
 
+	Columns []*Column
+
 	dependencies map[string]*Dependency
 	size         map[string]Size
 	config       map[string]string
@@ -39,11 +41,12 @@ func (p *Project) String() string {
 	return fmt.Sprintf("%v[%v]", p.Name, p.Type)
 }
 
-func (p *Project) AddDependency(d *Project) *Dependency {
+func (p *Project) AddDependency(d *Project, columns ...ColumnPair) *Dependency {
 	result := &Dependency{
-		Source: p,
-		Target: d,
-		config: map[string]string{},
+		Source:  p,
+		Target:  d,
+		Columns: columns,
+		config:  map[string]string{},
 	}
 
 	p.dependencies[d.Name] = result
@@ -51,6 +54,20 @@ func (p *Project) AddDependency(d *Project) *Dependency {
 	return result
 }
 
+func (p *Project) AddColumn(c *Column) {
+	p.Columns = append(p.Columns, c)
+}
+
+func (p *Project) GetColumn(name string) *Column {
+	for _, c := range p.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
 func (p *Project) AddSize(name string, size Size) {
 	p.size[name] = size
 }
@@ -186,9 +203,10 @@ func (p *Project) GetConfig(config string) string {
 }
 
 type Dependency struct {
-	Source *Project
-	Target *Project
-	config map[string]string
+	Source  *Project
+	Target  *Project
+	Columns []ColumnPair
+	config  map[string]string
 }
 
 func (d *Dependency) String() string {
@@ -289,6 +307,26 @@ func sortProjects(result []*Project) {
 	})
 }
 
+type Column struct {
+	Name     string
+	Type     string
+	Nullable bool
+	IsPK     bool
+	IsUnique bool
+}
+
+func (c *Column) String() string {
+	return c.Name
+}
+
+// ColumnPair joins a column of a Dependency's Source to the column of its
+// Target that it references, so FK edges can carry the columns that make
+// up the join rather than just the table-to-table relationship.
+type ColumnPair struct {
+	Source *Column
+	Target *Column
+}
+
 type Size struct {
 	Lines int
 	Files int
@@ -1,7 +1,9 @@
 package mysql
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -13,14 +15,33 @@ import (
 	"github.com/Faire/archer/lib/archer/common"
 )
 
+// fingerprintConfigKey is where the per-table fingerprint computed by
+// importTables is stashed, so it round-trips through WriteBasicInfoFile and
+// is available on the next run to decide whether a table can be skipped.
+const fingerprintConfigKey = "mysql.fingerprint"
+
+type rootAndName struct {
+	root string
+	name string
+}
+
 type mysqlImporter struct {
 	connectionString string
+	maxOpenConns     int
 	storage          *archer.Storage
 }
 
-func NewImporter(connectionString string) archer.Importer {
+// NewImporter creates an importer against connectionString. maxOpenConns
+// bounds how many connections information_schema is queried with
+// concurrently; pass 1 for the old, fully-serial behavior.
+func NewImporter(connectionString string, maxOpenConns int) archer.Importer {
+	if maxOpenConns <= 0 {
+		maxOpenConns = 1
+	}
+
 	return &mysqlImporter{
 		connectionString: connectionString,
+		maxOpenConns:     maxOpenConns,
 	}
 }
 
@@ -35,15 +56,20 @@ func (m *mysqlImporter) Import(projs *archer.Projects, storage *archer.Storage)
 	defer db.Close()
 
 	db.SetConnMaxLifetime(time.Minute)
-	db.SetMaxOpenConns(1)
-	db.SetMaxIdleConns(1)
+	db.SetMaxOpenConns(m.maxOpenConns)
+	db.SetMaxIdleConns(m.maxOpenConns)
 
-	err = m.importTables(db, projs)
+	changed, err := m.importTables(db, projs)
 	if err != nil {
 		return err
 	}
 
-	err = m.importFKs(db, projs)
+	err = m.importColumns(db, projs, changed)
+	if err != nil {
+		return err
+	}
+
+	err = m.importFKs(db, projs, changed)
 	if err != nil {
 		return err
 	}
@@ -51,45 +77,91 @@ func (m *mysqlImporter) Import(projs *archer.Projects, storage *archer.Storage)
 	return nil
 }
 
-func (m *mysqlImporter) importTables(db *sql.DB, projs *archer.Projects) error {
+// importTables queries table sizes together with a fingerprint of their
+// columns and FKs in a single joined round trip, so a fresh import over a
+// catalog with tens of thousands of tables doesn't need a query per table.
+// Tables whose fingerprint matches what was persisted on a previous run are
+// left alone entirely; only changed tables are returned for importColumns
+// and importFKs to write out.
+func (m *mysqlImporter) importTables(db *sql.DB, projs *archer.Projects) (map[rootAndName]bool, error) {
+	// group_concat_max_len defaults to 1024 bytes, which wide tables'
+	// columns/FK fingerprints below can exceed; a truncated fingerprint
+	// would hide a real schema change behind a false "unchanged" match.
+	_, err := db.Exec("set session group_concat_max_len = 1000000")
+	if err != nil {
+		return nil, errors.Wrap(err, "error raising group_concat_max_len")
+	}
+
 	results, err := db.Query(`
-		select TABLE_SCHEMA schema_name,
-			   TABLE_NAME   table_name,
-			   TABLE_ROWS   rows,
-			   DATA_LENGTH  data_size,
-			   INDEX_LENGTH index_size
-		from information_schema.TABLES
-		where TABLE_TYPE = 'BASE TABLE'
-		  and TABLE_SCHEMA <> 'information_schema'
+		select t.TABLE_SCHEMA schema_name,
+			   t.TABLE_NAME   table_name,
+			   t.TABLE_ROWS   rows,
+			   t.DATA_LENGTH  data_size,
+			   t.INDEX_LENGTH index_size,
+			   t.UPDATE_TIME  update_time,
+			   t.CREATE_TIME  create_time,
+			   coalesce(cols.columns_fingerprint, '') columns_fingerprint,
+			   coalesce(fks.fks_fingerprint, '')       fks_fingerprint
+		from information_schema.TABLES t
+			 left join (
+				 select TABLE_SCHEMA, TABLE_NAME,
+						group_concat(COLUMN_NAME, ':', COLUMN_TYPE, ':', IS_NULLABLE, ':', COLUMN_KEY
+									 order by ORDINAL_POSITION separator '|') columns_fingerprint
+				 from information_schema.COLUMNS
+				 group by TABLE_SCHEMA, TABLE_NAME
+			 ) cols on cols.TABLE_SCHEMA = t.TABLE_SCHEMA and cols.TABLE_NAME = t.TABLE_NAME
+			 left join (
+				 select TABLE_SCHEMA, TABLE_NAME,
+						group_concat(COLUMN_NAME, ':', REFERENCED_TABLE_NAME, ':', REFERENCED_COLUMN_NAME
+									 order by COLUMN_NAME, REFERENCED_TABLE_NAME separator '|') fks_fingerprint
+				 from information_schema.KEY_COLUMN_USAGE
+				 where REFERENCED_TABLE_NAME is not null
+				 group by TABLE_SCHEMA, TABLE_NAME
+			 ) fks on fks.TABLE_SCHEMA = t.TABLE_SCHEMA and fks.TABLE_NAME = t.TABLE_NAME
+		where t.TABLE_TYPE = 'BASE TABLE'
+		  and t.TABLE_SCHEMA <> 'information_schema'
 		`)
 	if err != nil {
-		return errors.Wrap(err, "error querying database tables")
+		return nil, errors.Wrap(err, "error querying database tables")
 	}
 
 	type tableInfo struct {
-		schemaName string
-		tableName  string
-		rows       int
-		dataSize   int
-		indexSize  int
+		schemaName         string
+		tableName          string
+		rows               int
+		dataSize           int
+		indexSize          int
+		updateTime         sql.NullTime
+		createTime         sql.NullTime
+		columnsFingerprint string
+		fksFingerprint     string
 	}
 
+	changed := map[rootAndName]bool{}
 	var changedProjs []*archer.Project
 
 	for results.Next() {
 		var table tableInfo
 
-		err = results.Scan(&table.schemaName, &table.tableName, &table.rows, &table.dataSize, &table.indexSize)
+		err = results.Scan(&table.schemaName, &table.tableName, &table.rows, &table.dataSize, &table.indexSize,
+			&table.updateTime, &table.createTime, &table.columnsFingerprint, &table.fksFingerprint)
 		if err != nil {
-			return errors.Wrap(err, "error querying database tables")
+			return nil, errors.Wrap(err, "error querying database tables")
 		}
 
-		fmt.Printf("Importing table %v.%v (%v data, %v indexes)\n", table.schemaName, table.tableName,
-			humanize.Bytes(uint64(table.dataSize)), humanize.Bytes(uint64(table.indexSize)))
-
 		proj := projs.Get(table.schemaName, table.tableName)
 		proj.Type = archer.DatabaseType
 
+		fingerprint := fingerprintOf(table.rows, table.dataSize, table.indexSize,
+			table.updateTime, table.createTime, table.columnsFingerprint, table.fksFingerprint)
+
+		if proj.GetConfig(fingerprintConfigKey) == fingerprint {
+			continue
+		}
+
+		fmt.Printf("Importing table %v.%v (%v data, %v indexes)\n", table.schemaName, table.tableName,
+			humanize.Bytes(uint64(table.dataSize)), humanize.Bytes(uint64(table.indexSize)))
+
 		proj.AddSize("table", archer.Size{
 			Lines: table.rows,
 			Bytes: table.dataSize + table.indexSize,
@@ -99,6 +171,9 @@ func (m *mysqlImporter) importTables(db *sql.DB, projs *archer.Projects) error {
 			},
 		})
 
+		proj.SetConfig(fingerprintConfigKey, fingerprint)
+
+		changed[rootAndName{table.schemaName, table.tableName}] = true
 		changedProjs = append(changedProjs, proj)
 	}
 
@@ -107,10 +182,101 @@ func (m *mysqlImporter) importTables(db *sql.DB, projs *archer.Projects) error {
 	for _, proj := range changedProjs {
 		err = m.storage.WriteBasicInfoFile(proj)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		err = m.storage.WriteSizeFile(proj)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return changed, nil
+}
+
+func fingerprintOf(
+	rows, dataSize, indexSize int, updateTime, createTime sql.NullTime, columnsFingerprint, fksFingerprint string,
+) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%v|%v|%v|%v|%v|%v|%v",
+		rows, dataSize, indexSize, formatNullTime(updateTime), formatNullTime(createTime),
+		columnsFingerprint, fksFingerprint)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func formatNullTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+
+	return t.Time.UTC().Format(time.RFC3339)
+}
+
+func (m *mysqlImporter) importColumns(db *sql.DB, projs *archer.Projects, changed map[rootAndName]bool) error {
+	results, err := db.Query(`
+		select TABLE_SCHEMA schema_name,
+			   TABLE_NAME   table_name,
+			   COLUMN_NAME  column_name,
+			   COLUMN_TYPE  column_type,
+			   IS_NULLABLE  is_nullable,
+			   COLUMN_KEY   column_key
+		from information_schema.COLUMNS
+		where TABLE_SCHEMA <> 'information_schema'
+		order by TABLE_SCHEMA, TABLE_NAME, ORDINAL_POSITION
+		`)
+	if err != nil {
+		return errors.Wrap(err, "error querying database columns")
+	}
+
+	type columnInfo struct {
+		schemaName string
+		tableName  string
+		columnName string
+		columnType string
+		isNullable string
+		columnKey  string
+	}
+
+	cleared := map[rootAndName]bool{}
+
+	for results.Next() {
+		var c columnInfo
+
+		err = results.Scan(&c.schemaName, &c.tableName, &c.columnName, &c.columnType, &c.isNullable, &c.columnKey)
+		if err != nil {
+			return errors.Wrap(err, "error querying database columns")
+		}
+
+		key := rootAndName{c.schemaName, c.tableName}
+		if !changed[key] {
+			continue
+		}
+
+		proj := projs.Get(c.schemaName, c.tableName)
+
+		// proj.Columns may still hold whatever a prior run's
+		// WriteColumnsFile loaded it with; since this table's fingerprint
+		// changed, that snapshot is stale, so start it over instead of
+		// appending onto it.
+		if !cleared[key] {
+			proj.Columns = nil
+			cleared[key] = true
+		}
+
+		proj.AddColumn(&archer.Column{
+			Name:     c.columnName,
+			Type:     c.columnType,
+			Nullable: c.isNullable == "YES",
+			IsPK:     c.columnKey == "PRI",
+			IsUnique: c.columnKey == "PRI" || c.columnKey == "UNI",
+		})
+	}
+
+	for k := range changed {
+		proj := projs.Get(k.root, k.name)
+
+		err = m.storage.WriteColumnsFile(proj)
 		if err != nil {
 			return err
 		}
@@ -119,55 +285,82 @@ func (m *mysqlImporter) importTables(db *sql.DB, projs *archer.Projects) error {
 	return nil
 }
 
-func (m *mysqlImporter) importFKs(db *sql.DB, projs *archer.Projects) error {
+func (m *mysqlImporter) importFKs(db *sql.DB, projs *archer.Projects, changed map[rootAndName]bool) error {
 	results, err := db.Query(`
-		select CONSTRAINT_SCHEMA schema_name,
-			   TABLE_NAME,
-			   REFERENCED_TABLE_NAME
-		from information_schema.REFERENTIAL_CONSTRAINTS
+		select TABLE_SCHEMA           schema_name,
+			   TABLE_NAME             table_name,
+			   COLUMN_NAME            column_name,
+			   REFERENCED_TABLE_NAME  referenced_table_name,
+			   REFERENCED_COLUMN_NAME referenced_column_name
+		from information_schema.KEY_COLUMN_USAGE
+		where REFERENCED_TABLE_NAME is not null
 		`)
 	if err != nil {
 		return errors.Wrap(err, "error querying database FKs")
 	}
 
 	type fkInfo struct {
-		schemaName          string
-		tableName           string
-		referencedTableName string
+		schemaName           string
+		tableName            string
+		columnName           string
+		referencedTableName  string
+		referencedColumnName string
 	}
 
-	type rootAndName struct {
-		root string
-		name string
+	type fkKey struct {
+		rootAndName
+		referencedTableName string
 	}
-	toSave := map[rootAndName]bool{}
+
+	var order []fkKey
+	columns := map[fkKey][]archer.ColumnPair{}
 
 	for results.Next() {
 		var fk fkInfo
 
-		err = results.Scan(&fk.schemaName, &fk.tableName, &fk.referencedTableName)
+		err = results.Scan(&fk.schemaName, &fk.tableName, &fk.columnName, &fk.referencedTableName, &fk.referencedColumnName)
 		if err != nil {
 			return errors.Wrap(err, "error querying database FKs")
 		}
 
-		fmt.Printf("Importing dependency %v.%v => %v.%v\n",
-			fk.schemaName, fk.tableName, fk.schemaName, fk.referencedTableName)
-
 		proj := projs.Get(fk.schemaName, fk.tableName)
-
 		dep := projs.Get(fk.schemaName, fk.referencedTableName)
-		proj.AddDependency(dep)
 
-		toSave[rootAndName{fk.schemaName, fk.tableName}] = true
+		key := fkKey{rootAndName{fk.schemaName, fk.tableName}, fk.referencedTableName}
+		if _, ok := columns[key]; !ok {
+			order = append(order, key)
+		}
+
+		columns[key] = append(columns[key], archer.ColumnPair{
+			Source: proj.GetColumn(fk.columnName),
+			Target: dep.GetColumn(fk.referencedColumnName),
+		})
+	}
+
+	for _, key := range order {
+		proj := projs.Get(key.root, key.name)
+		dep := projs.Get(key.root, key.referencedTableName)
+
+		if changed[key.rootAndName] {
+			fmt.Printf("Importing dependency %v.%v => %v.%v\n",
+				key.root, key.name, key.root, key.referencedTableName)
+		}
+
+		proj.AddDependency(dep, columns[key]...)
 	}
 
-	for k := range toSave {
+	for k := range changed {
 		proj := projs.Get(k.root, k.name)
 
 		err = m.storage.WriteDepsFile(proj)
 		if err != nil {
 			return err
 		}
+
+		err = m.storage.WriteSchemaFile(proj)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil